@@ -0,0 +1,118 @@
+package light
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// HandlerFunc is like http.HandlerFunc, but returns an error instead of
+// writing the error response itself. MethodE and the HTTP-method-specific
+// *E methods hand a non-nil error off to the Router's ErrorHandler, which
+// removes the `if err != nil { writeJSON(w, 400, ...); return }`
+// boilerplate from every handler.
+type HandlerFunc func(http.ResponseWriter, *http.Request) error
+
+// ErrorHandler renders the response for an error returned by a HandlerFunc.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// HTTPError is a HandlerFunc error carrying an explicit HTTP status and a
+// client-safe message. The default ErrorHandler renders it as that status
+// with a JSON body of the form {"error": Message}; any other error is
+// treated as an opaque 500.
+type HTTPError struct {
+	Status  int
+	Message string
+	Cause   error
+}
+
+func (e *HTTPError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Cause
+}
+
+// defaultErrorHandler renders an *HTTPError as JSON with its Status; any
+// other error is logged via slog and reported as a bare 500.
+func defaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		writeJSONError(w, httpErr.Status, httpErr.Message)
+		return
+	}
+
+	slog.ErrorContext(r.Context(), "unhandled handler error", "error", err)
+	writeJSONError(w, http.StatusInternalServerError, "internal server error")
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	body, _ := json.Marshal(struct {
+		Error string `json:"error"`
+	}{message})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func (r *routerContext) SetErrorHandler(eh ErrorHandler) {
+	r.errorHandler = eh
+}
+
+func (r *routerContext) MethodE(method, pattern string, h HandlerFunc) {
+	eh := r.errorHandler
+
+	r.Method(method, pattern, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		err := h(w, req)
+		if err == nil {
+			return
+		}
+
+		if rc, ok := RouteContext(req); ok {
+			rc.err = err
+		}
+		eh(w, req, err)
+	}))
+}
+
+func (r *routerContext) ConnectE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodConnect, pattern, h)
+}
+
+func (r *routerContext) DeleteE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodDelete, pattern, h)
+}
+
+func (r *routerContext) GetE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodGet, pattern, h)
+}
+
+func (r *routerContext) HeadE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodHead, pattern, h)
+}
+
+func (r *routerContext) OptionsE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodOptions, pattern, h)
+}
+
+func (r *routerContext) PatchE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodPatch, pattern, h)
+}
+
+func (r *routerContext) PostE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodPost, pattern, h)
+}
+
+func (r *routerContext) PutE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodPut, pattern, h)
+}
+
+func (r *routerContext) TraceE(pattern string, h HandlerFunc) {
+	r.MethodE(http.MethodTrace, pattern, h)
+}
@@ -0,0 +1,68 @@
+package light_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gohttp "github.com/hysem/go-http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_GetE_DefaultErrorHandler(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.GetE("/widgets/{id}", func(w http.ResponseWriter, req *http.Request) error {
+		return &gohttp.HTTPError{Status: http.StatusNotFound, Message: "widget not found"}
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/widgets/1", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error":"widget not found"}`, rec.Body.String())
+}
+
+func TestRouter_GetE_CustomErrorHandler(t *testing.T) {
+	r := gohttp.NewRouter()
+
+	var observedErr error
+	r.SetErrorHandler(func(w http.ResponseWriter, req *http.Request, err error) {
+		observedErr = err
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	boom := errors.New("boom")
+	r.GetE("/brew", func(w http.ResponseWriter, req *http.Request) error {
+		return boom
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/brew", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, boom, observedErr)
+}
+
+func TestRouter_GetE_RouteCtxErr(t *testing.T) {
+	r := gohttp.NewRouter()
+
+	boom := errors.New("boom")
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req)
+			rc, ok := gohttp.RouteContext(req)
+			assert.True(t, ok)
+			assert.Equal(t, boom, rc.Err())
+		})
+	})
+	r.GetE("/brew", func(w http.ResponseWriter, req *http.Request) error {
+		return boom
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/brew", nil)
+	r.ServeHTTP(rec, req)
+}
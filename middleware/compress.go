@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Compress returns a middleware that gzip- or deflate-encodes the response
+// body when the client's Accept-Encoding allows it. level is passed straight
+// to the compress/* writer (e.g. gzip.DefaultCompression). When types is
+// non-empty, only responses whose Content-Type starts with one of them are
+// compressed; an empty types compresses every response.
+func Compress(level int, types ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The response varies on Accept-Encoding whether or not this
+			// particular request ends up compressed, so a cache in front of
+			// this handler must key on it too.
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, types: types, encoding: encoding}
+			defer cw.Close()
+
+			switch encoding {
+			case "gzip":
+				gz, err := gzip.NewWriterLevel(w, level)
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				cw.writer = gz
+			case "deflate":
+				fl, err := flate.NewWriter(w, level)
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				cw.writer = fl
+			}
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// compressWriter defers the compress/don't-compress decision to the first
+// Write (or WriteHeader) call, once the handler's Content-Type is known.
+type compressWriter struct {
+	http.ResponseWriter
+	writer      io.WriteCloser
+	encoding    string
+	types       []string
+	decided     bool
+	compressing bool
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	w.decide()
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	w.decide()
+	if w.compressing {
+		return w.writer.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *compressWriter) Close() error {
+	if w.compressing {
+		return w.writer.Close()
+	}
+	return nil
+}
+
+func (w *compressWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+
+	if len(w.types) > 0 {
+		ct := w.Header().Get("Content-Type")
+		w.compressing = false
+		for _, t := range w.types {
+			if strings.HasPrefix(ct, t) {
+				w.compressing = true
+				break
+			}
+		}
+	} else {
+		w.compressing = true
+	}
+
+	if w.compressing {
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Encoding", w.encoding)
+	}
+}
+
+// negotiateEncoding picks the highest-q gzip or deflate encoding from
+// acceptEncoding, honouring explicit q-values so "gzip;q=0" (the client
+// explicitly declining gzip) is correctly treated as unacceptable rather
+// than as a bare token match.
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+
+	for _, enc := range strings.Split(acceptEncoding, ",") {
+		name, q := parseEncoding(enc)
+		switch name {
+		case "gzip", "deflate":
+		default:
+			continue
+		}
+
+		if q > 0 && q > bestQ {
+			best, bestQ = name, q
+		}
+	}
+
+	return best
+}
+
+// parseEncoding splits a single Accept-Encoding token ("gzip;q=0.8") into its
+// name and q-value, defaulting to q=1 when none is given.
+func parseEncoding(enc string) (name string, q float64) {
+	parts := strings.SplitN(enc, ";", 2)
+	name = strings.TrimSpace(parts[0])
+	q = 1
+
+	if len(parts) != 2 {
+		return name, q
+	}
+
+	qParam, ok := strings.CutPrefix(strings.TrimSpace(parts[1]), "q=")
+	if !ok {
+		return name, q
+	}
+
+	if parsed, err := strconv.ParseFloat(qParam, 64); err == nil {
+		q = parsed
+	}
+
+	return name, q
+}
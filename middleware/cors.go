@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures CORS.
+type CORSOptions struct {
+	// AllowedOrigins lists origins allowed to make cross-origin requests. A
+	// single "*" allows any origin, but per the fetch spec is incompatible
+	// with AllowCredentials.
+	AllowedOrigins []string
+	// AllowedMethods lists the methods advertised in a preflight response.
+	AllowedMethods []string
+	// AllowedHeaders lists the request headers advertised in a preflight
+	// response.
+	AllowedHeaders []string
+	// AllowCredentials sets Access-Control-Allow-Credentials.
+	AllowCredentials bool
+	// MaxAge caches a preflight response for the given duration.
+	MaxAge time.Duration
+}
+
+// CORS returns a middleware that answers CORS preflight (OPTIONS) requests
+// and annotates actual cross-origin requests, per opts.
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	allowed := make(map[string]struct{}, len(opts.AllowedOrigins))
+	wildcard := false
+	for _, o := range opts.AllowedOrigins {
+		if o == "*" {
+			wildcard = true
+		}
+		allowed[o] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if _, ok := allowed[origin]; !ok && !wildcard {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("Vary", "Origin")
+			if wildcard && !opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			if opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				if len(opts.AllowedMethods) > 0 {
+					h.Set("Access-Control-Allow-Methods", strings.Join(opts.AllowedMethods, ", "))
+				}
+				if len(opts.AllowedHeaders) > 0 {
+					h.Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+				}
+				if opts.MaxAge > 0 {
+					h.Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	gohttp "github.com/hysem/go-http"
+)
+
+// Logger returns a middleware that logs one structured log/slog line per
+// request: method, path, status, response size, duration, and the request
+// ID stashed by RequestID if that middleware ran earlier in the chain. path
+// is the route's registered pattern (e.g. "/users/{id}") when Logger runs
+// inside a Router's middleware stack and a route matched, falling back to the
+// literal URL path otherwise (including when Logger wraps a Router from
+// outside). A nil
+// logger falls back to slog.Default().
+func Logger(logger *slog.Logger) func(http.Handler) http.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r)
+
+			// Prefer the matched route's pattern ("/users/{id}") over the
+			// literal URL so logs and any downstream aggregation don't end
+			// up with one high-cardinality label per distinct ID.
+			path := r.URL.Path
+			if rc, ok := gohttp.RouteContext(r); ok && rc.Pattern != "" {
+				path = rc.Pattern
+			}
+
+			args := []any{
+				"method", r.Method,
+				"path", path,
+				"status", sw.status,
+				"bytes", sw.bytes,
+				"duration", time.Since(start),
+			}
+			if id, ok := RequestIDFromContext(r.Context()); ok {
+				args = append(args, "request_id", id)
+			}
+
+			logger.InfoContext(r.Context(), "request", args...)
+		})
+	}
+}
+
+// statusWriter records the status code and byte count written through it so
+// Logger can report them after the handler returns.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
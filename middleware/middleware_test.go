@@ -0,0 +1,230 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gohttp "github.com/hysem/go-http"
+	"github.com/hysem/go-http/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Get("/ping", func(w http.ResponseWriter, req *http.Request) {
+		id, ok := middleware.RequestIDFromContext(req.Context())
+		assert.True(t, ok, "request ID should be present in context")
+		assert.NotEmpty(t, id)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.NotEmpty(t, rec.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestRecoverer(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.Recoverer)
+	r.Get("/boom", func(w http.ResponseWriter, req *http.Request) {
+		panic("kaboom")
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.CORS(middleware.CORSOptions{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+	}))
+	r.Get("/widgets", func(w http.ResponseWriter, req *http.Request) {})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/widgets", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestThrottle(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.Throttle(1, 0))
+
+	block := make(chan struct{})
+	r.Get("/slow", func(w http.ResponseWriter, req *http.Request) {
+		<-block
+	})
+
+	done := make(chan struct{})
+	go func() {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+		r.ServeHTTP(rec, req)
+		close(done)
+	}()
+
+	// give the first request time to acquire the single slot.
+	time.Sleep(10 * time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	close(block)
+	<-done
+}
+
+func TestRealIP(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.RealIP("10.0.0.1"))
+	r.Get("/ip", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, req.RemoteAddr)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "10.0.0.1:12345"
+	req.Header.Set("X-Forwarded-For", "203.0.113.5, 10.0.0.1")
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, "203.0.113.5", rec.Body.String())
+}
+
+func TestRealIP_UntrustedPeerIsNotRewritten(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.RealIP("10.0.0.1"))
+	r.Get("/ip", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, req.RemoteAddr)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "203.0.113.9:12345"
+	req.Header.Set("X-Forwarded-For", "9.9.9.9")
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, "203.0.113.9:12345", rec.Body.String())
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	r := gohttp.NewRouter()
+	r.Use(middleware.Logger(logger))
+	r.Get("/users/{id}", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	r.ServeHTTP(rec, req)
+
+	out := buf.String()
+	assert.Contains(t, out, "method=GET")
+	assert.Contains(t, out, "status=200")
+	assert.Contains(t, out, "path=/users/{id}")
+}
+
+func TestLogger_FallsBackToURLPathOutsideRouter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	handler := middleware.Logger(logger)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	handler.ServeHTTP(rec, req)
+
+	assert.Contains(t, buf.String(), "path=/users/42")
+}
+
+func TestTimeout(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.Timeout(10 * time.Millisecond))
+	r.Get("/slow", func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+
+	done := make(chan struct{})
+	go func() {
+		r.ServeHTTP(rec, req)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Timeout did not cut the request short")
+	}
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+}
+
+func TestCompress(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.Compress(gzip.DefaultCompression))
+	r.Get("/text", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "hello world")
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/text", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+
+	gz, err := gzip.NewReader(rec.Body)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(gz)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", string(body))
+}
+
+func TestCompress_QZeroIsRejected(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Use(middleware.Compress(gzip.DefaultCompression))
+	r.Get("/text", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, "hello world")
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/text", nil)
+	req.Header.Set("Accept-Encoding", "gzip;q=0")
+	r.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "hello world", rec.Body.String())
+	assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RealIP returns a middleware that rewrites r.RemoteAddr from the
+// X-Forwarded-For or X-Real-IP header, but only when the request's
+// immediate peer (r.RemoteAddr) is one of trustedProxies. Honouring these
+// headers from an untrusted peer lets a client spoof its own IP, so the
+// rewrite is skipped unless the request actually arrived through a proxy we
+// recognise.
+func RealIP(trustedProxies ...string) func(http.Handler) http.Handler {
+	trusted := make(map[string]struct{}, len(trustedProxies))
+	for _, p := range trustedProxies {
+		trusted[p] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+				if _, ok := trusted[host]; ok {
+					if ip := forwardedFor(r, trusted); ip != "" {
+						r.RemoteAddr = ip
+					}
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedFor returns the real client IP from X-Real-IP, or from
+// X-Forwarded-For otherwise. Proxies append the IP they observed to
+// X-Forwarded-For rather than overwrite it, so the real client is the
+// rightmost entry that isn't itself one of trusted — taking the leftmost
+// entry instead would let a client connecting directly to our trusted proxy
+// supply its own (spoofed) entry and have it win.
+func forwardedFor(r *http.Request, trusted map[string]struct{}) string {
+	if xrip := r.Header.Get("X-Real-IP"); xrip != "" {
+		return xrip
+	}
+
+	xff := r.Header.Get("X-Forwarded-For")
+	if xff == "" {
+		return ""
+	}
+
+	parts := strings.Split(xff, ",")
+	for i := len(parts) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(parts[i])
+		if _, ok := trusted[ip]; ok {
+			continue
+		}
+		return ip
+	}
+	return ""
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recoverer recovers panics from downstream handlers, logs the panic value
+// and stack trace via log/slog, and responds with a bare 500 so a single bad
+// request can't take the whole server down.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.ErrorContext(r.Context(), "panic recovered",
+					"error", rec,
+					"stack", string(debug.Stack()),
+				)
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"net/http"
+)
+
+type requestIDKey struct{}
+
+// RequestIDHeader is the response header RequestID echoes the generated ID on.
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID generates a short random ID for the request, stashes it in the
+// request context and echoes it back via the X-Request-Id response header,
+// so downstream middlewares (Logger) and handlers can tag their output with
+// it.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := newRequestID()
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// RequestIDFromContext returns the request ID stashed by RequestID, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+func newRequestID() string {
+	var b [12]byte
+	_, _ = rand.Read(b[:])
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b[:])
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// Throttle bounds the number of in-flight requests to n. Once n requests are
+// being handled concurrently, further requests wait in a backlog of up to
+// backlogTimeout for a slot to free up, responding 429 if one doesn't in
+// time. A backlogTimeout of zero rejects immediately instead of waiting.
+func Throttle(n int, backlogTimeout time.Duration) func(http.Handler) http.Handler {
+	sem := make(chan struct{}, n)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				next.ServeHTTP(w, r)
+				return
+			default:
+			}
+
+			if backlogTimeout <= 0 {
+				http.Error(w, "too many requests", http.StatusTooManyRequests)
+				return
+			}
+
+			timer := time.NewTimer(backlogTimeout)
+			defer timer.Stop()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				next.ServeHTTP(w, r)
+			case <-timer.C:
+				http.Error(w, "too many requests", http.StatusTooManyRequests)
+			case <-r.Context().Done():
+			}
+		})
+	}
+}
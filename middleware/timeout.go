@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Timeout cancels the request context after d elapses, so downstream code
+// selecting on ctx.Done() (DB calls, outbound requests) unwinds instead of
+// leaking, and responds with 504 Gateway Timeout on next's behalf if it
+// hasn't written anything by then. It's a small reimplementation of the
+// relevant part of http.TimeoutHandler rather than a wrapper around it,
+// since http.TimeoutHandler hardcodes its own timeout response to 503
+// Service Unavailable.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{ResponseWriter: w}
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+				next.ServeHTTP(tw, r.WithContext(ctx))
+			}()
+
+			select {
+			case <-done:
+				// next may have returned only because ctx expired, in which
+				// case done and ctx.Done() race; that is still a timeout.
+				if ctx.Err() != context.DeadlineExceeded {
+					return
+				}
+			case <-ctx.Done():
+			}
+
+			tw.mu.Lock()
+			wroteAlready := tw.wroteHeader
+			tw.wroteHeader = true
+			tw.timedOut = true
+			tw.mu.Unlock()
+
+			if !wroteAlready {
+				w.WriteHeader(http.StatusGatewayTimeout)
+				fmt.Fprint(w, http.StatusText(http.StatusGatewayTimeout))
+			}
+		})
+	}
+}
+
+// timeoutWriter guards w against next, which keeps running in its own
+// goroutine past d: once Timeout has sent the 504 on next's behalf, writes
+// from next's goroutine are dropped instead of racing with or trailing
+// after it.
+type timeoutWriter struct {
+	http.ResponseWriter
+	mu          sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (w *timeoutWriter) WriteHeader(status int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut || w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
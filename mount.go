@@ -0,0 +1,158 @@
+package light
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// sentinelResponseWriter buffers a response's status and body so
+// routerContext.ServeHTTP can detect the default http.ServeMux 404/405
+// responses and swap in the user-registered NotFound/MethodNotAllowed handler
+// before any status or body reaches the real http.ResponseWriter. Header()
+// is not buffered: headers set by the default responder stay on the real
+// writer's header map.
+type sentinelResponseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	body        []byte
+	wroteHeader bool
+}
+
+func (s *sentinelResponseWriter) WriteHeader(code int) {
+	if s.wroteHeader {
+		return
+	}
+	s.wroteHeader = true
+	s.statusCode = code
+}
+
+func (s *sentinelResponseWriter) Write(b []byte) (int, error) {
+	if !s.wroteHeader {
+		s.WriteHeader(http.StatusOK)
+	}
+	s.body = append(s.body, b...)
+	return len(b), nil
+}
+
+// flush emits whatever was buffered to the real http.ResponseWriter.
+func (s *sentinelResponseWriter) flush() {
+	if s.statusCode == 0 {
+		s.statusCode = http.StatusOK
+	}
+	s.ResponseWriter.WriteHeader(s.statusCode)
+	if len(s.body) > 0 {
+		s.ResponseWriter.Write(s.body)
+	}
+}
+
+// wrapMiddlewares applies r's accumulated middleware stack to h, innermost
+// first, matching the order Method wraps handlers in.
+func (r *routerContext) wrapMiddlewares(h http.Handler) http.Handler {
+	wrapped := h
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		wrapped = r.middlewares[i](wrapped)
+	}
+	return wrapped
+}
+
+func (r *routerContext) Mount(pattern string, h http.Handler) {
+	full := strings.TrimSuffix(fmt.Sprintf("%s%s", r.pattern, pattern), "/")
+
+	stripped := http.StripPrefix(full, h)
+	wrapped := r.wrapMiddlewares(stripped)
+
+	r.registry.add(&routeEntry{
+		pattern:     full + "/",
+		handler:     h,
+		middlewares: append([]func(http.Handler) http.Handler(nil), r.middlewares...),
+	})
+
+	// Register both the bare prefix and the subtree pattern so the mount is
+	// reachable at the exact prefix as well as any subpath, without relying
+	// on ServeMux's redirect-to-trailing-slash behaviour.
+	r.Handle(full, wrapped)
+	r.Handle(full+"/", wrapped)
+}
+
+func (r *routerContext) NotFound(h http.HandlerFunc) {
+	r.registry.mu.Lock()
+	r.registry.notFound = r.wrapMiddlewares(h)
+	r.registry.mu.Unlock()
+}
+
+func (r *routerContext) MethodNotAllowed(h http.HandlerFunc) {
+	r.registry.mu.Lock()
+	r.registry.methodNotAllowed = r.wrapMiddlewares(h)
+	r.registry.mu.Unlock()
+}
+
+func (r *routerContext) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if _, ok := RouteContext(req); !ok {
+		req = req.WithContext(context.WithValue(req.Context(), routeContextKey{}, &RouteCtx{}))
+	}
+
+	// ServeMux.Handler resolves the request to its handler (and the
+	// pattern it matched) without running it, so we can peek at whether
+	// this is a genuine route match before committing to dispatch it. A
+	// match is served through r.ServeMux.ServeHTTP itself (not h directly)
+	// so ServeMux still gets to populate r.PathValue for this request; h
+	// alone skips that bookkeeping. Only the no-match case, where pattern
+	// is "" and the returned handler is ServeMux's own built-in 404/405
+	// responder, needs the sentinel trick below.
+	h, pattern := r.ServeMux.Handler(req)
+	if pattern != "" {
+		r.ServeMux.ServeHTTP(w, req)
+		return
+	}
+
+	r.registry.mu.Lock()
+	notFound, methodNotAllowed := r.registry.notFound, r.registry.methodNotAllowed
+	r.registry.mu.Unlock()
+
+	// An OPTIONS request for a path that IS registered, just not for
+	// OPTIONS, lands here too: method-specific patterns make ServeMux
+	// treat "path matches, method doesn't" as a 405, same as any other
+	// method mismatch. Route it through the middleware stack ending in a
+	// no-op responder instead of letting it 405 outright, so a CORS
+	// middleware earlier in the stack gets a chance to answer the
+	// preflight - otherwise no route could ever satisfy a preflight
+	// without also registering an explicit (and otherwise pointless)
+	// OPTIONS handler.
+	needsSentinel := notFound != nil || methodNotAllowed != nil || req.Method == http.MethodOptions
+	if !needsSentinel {
+		h.ServeHTTP(w, req)
+		return
+	}
+
+	sentinel := &sentinelResponseWriter{ResponseWriter: w}
+	h.ServeHTTP(sentinel, req)
+
+	switch sentinel.statusCode {
+	case http.StatusNotFound:
+		if notFound != nil {
+			notFound.ServeHTTP(w, req)
+			return
+		}
+	case http.StatusMethodNotAllowed:
+		if req.Method == http.MethodOptions {
+			r.wrapMiddlewares(http.HandlerFunc(optionsNoop)).ServeHTTP(w, req)
+			return
+		}
+		if methodNotAllowed != nil {
+			methodNotAllowed.ServeHTTP(w, req)
+			return
+		}
+	}
+
+	sentinel.flush()
+}
+
+// optionsNoop is the terminal handler for an OPTIONS request whose path
+// matched a route registered under other methods but not OPTIONS itself.
+// If no middleware (e.g. CORS) short-circuits it first, it just replies
+// with an empty 204.
+func optionsNoop(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package light_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gohttp "github.com/hysem/go-http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Mount(t *testing.T) {
+	mounted := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "mounted:%s", r.URL.Path)
+	})
+
+	r := gohttp.NewRouter()
+	r.Mount("/api", mounted)
+
+	testCases := map[string]string{
+		"/api":         "mounted:",
+		"/api/":        "mounted:/",
+		"/api/widgets": "mounted:/widgets",
+	}
+
+	for endpoint, want := range testCases {
+		endpoint, want := endpoint, want
+		t.Run(endpoint, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, endpoint, nil)
+			r.ServeHTTP(rec, req)
+			assert.Equal(t, want, rec.Body.String())
+		})
+	}
+}
+
+func TestRouter_NotFound(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users", handler)
+
+	middlewareRan := false
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			middlewareRan = true
+			next.ServeHTTP(w, req)
+		})
+	})
+	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		fmt.Fprint(w, "custom 404")
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "custom 404", rec.Body.String())
+	assert.True(t, middlewareRan, "NotFound should run through the accumulated middleware stack")
+}
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users", handler)
+
+	middlewareRan := false
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			middlewareRan = true
+			next.ServeHTTP(w, req)
+		})
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		fmt.Fprint(w, "custom 405")
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "custom 405", rec.Body.String())
+	assert.True(t, middlewareRan, "MethodNotAllowed should run through the accumulated middleware stack")
+}
+
+func TestRouter_NotFound_DoesNotAffectMatchedRoutes(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users", handler)
+	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.NotEqual(t, http.StatusTeapot, rec.Code)
+}
+
+func TestRouter_Options_NoopWithoutCORS(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users", handler)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/users", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code, "an OPTIONS request for a registered path should not 405 just because no method handles OPTIONS itself")
+}
+
+func TestRouter_Options_UnregisteredPathStill404s(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users", handler)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/missing", nil)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,147 @@
+// Package openapi builds an OpenAPI 3.0/3.1 document from a gohttp.Router by
+// walking its registered routes, mirroring the way swaggest/rest captures
+// handlers and middlewares at registration time so the spec can be produced
+// without re-parsing routes.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gohttp "github.com/hysem/go-http"
+)
+
+// Operation is an alias of gohttp.Operation so callers can describe routes
+// using either gohttp.Operation or openapi.Operation interchangeably.
+type Operation = gohttp.Operation
+
+// Info is the OpenAPI `info` object.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Parameter is an OpenAPI path/query/header parameter.
+type Parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Schema   any    `json:"schema,omitempty"`
+}
+
+// Operation-level fields that Document actually emits, combining what
+// Describe attached with what Walk derived from the pattern.
+type operationDoc struct {
+	Summary     string         `json:"summary,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Parameters  []Parameter    `json:"parameters,omitempty"`
+	RequestBody any            `json:"requestBody,omitempty"`
+	Responses   map[string]any `json:"responses"`
+}
+
+// PathItem groups the operations registered for a single path, keyed by
+// lower-case HTTP method (e.g. "get", "post").
+type PathItem map[string]*operationDoc
+
+// Document is the root OpenAPI document produced by Generate.
+type Document struct {
+	OpenAPI string              `json:"openapi"`
+	Info    Info                `json:"info"`
+	Paths   map[string]PathItem `json:"paths"`
+}
+
+// version is the OpenAPI document version Generate emits. Callers targeting
+// 3.1 instead of 3.0 can simply overwrite Document.OpenAPI afterwards.
+const version = "3.0.3"
+
+var pathParam = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// Generate walks r and produces an OpenAPI document describing its routes.
+// Metadata attached via Router.Describe is merged into the matching
+// operation; routes without a Describe call still get a bare operation entry
+// derived from the method and pattern alone.
+func Generate(r gohttp.Router, info Info) (*Document, error) {
+	doc := &Document{
+		OpenAPI: version,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+	}
+
+	err := r.Walk(func(method, pattern string, _ http.Handler, _ []func(http.Handler) http.Handler) error {
+		if method == "" {
+			return nil
+		}
+
+		openapiPath := toOpenAPIPath(pattern)
+
+		item, ok := doc.Paths[openapiPath]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[openapiPath] = item
+		}
+
+		op := &operationDoc{
+			Parameters: pathParameters(pattern),
+			Responses:  map[string]any{"200": map[string]any{"description": "OK"}},
+		}
+
+		if meta, ok := r.Operation(method, pattern); ok {
+			op.Summary = meta.Summary
+			op.Description = meta.Description
+			op.Tags = meta.Tags
+			op.RequestBody = meta.RequestBody
+			if len(meta.Responses) > 0 {
+				op.Responses = make(map[string]any, len(meta.Responses))
+				for status, body := range meta.Responses {
+					op.Responses[strconv.Itoa(status)] = body
+				}
+			}
+		}
+
+		item[strings.ToLower(method)] = op
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// toOpenAPIPath strips the host portion net/http 1.22 patterns allow
+// ("host/path") and the trailing "..." wildcard net/http uses for subtree
+// matches, since OpenAPI paths only describe the path component.
+func toOpenAPIPath(pattern string) string {
+	if idx := strings.Index(pattern, "/"); idx > 0 && !strings.Contains(pattern[:idx], "{") {
+		pattern = pattern[idx:]
+	}
+	if strings.HasSuffix(pattern, "...}") {
+		pattern = strings.TrimSuffix(pattern, "...}") + "}"
+	}
+	return pattern
+}
+
+// pathParameters derives the OpenAPI path parameters from the `{name}`
+// wildcard segments net/http 1.22's ServeMux parses out of pattern.
+func pathParameters(pattern string) []Parameter {
+	matches := pathParam.FindAllStringSubmatch(pattern, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	params := make([]Parameter, 0, len(matches))
+	for _, m := range matches {
+		name := strings.TrimSuffix(m[1], "...")
+		params = append(params, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   map[string]string{"type": "string"},
+		})
+	}
+	return params
+}
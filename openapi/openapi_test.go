@@ -0,0 +1,47 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	gohttp "github.com/hysem/go-http"
+	"github.com/hysem/go-http/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users/{id}", func(w http.ResponseWriter, req *http.Request) {})
+	r.Describe(http.MethodGet, "/users/{id}", openapi.Operation{Summary: "Get a user"})
+	r.Post("/users", func(w http.ResponseWriter, req *http.Request) {})
+
+	doc, err := openapi.Generate(r, openapi.Info{Title: "Test API", Version: "1.0.0"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Test API", doc.Info.Title)
+
+	getOp := doc.Paths["/users/{id}"]["get"]
+	assert.NotNil(t, getOp)
+	assert.Equal(t, "Get a user", getOp.Summary)
+	if assert.Len(t, getOp.Parameters, 1) {
+		assert.Equal(t, "id", getOp.Parameters[0].Name)
+		assert.Equal(t, "path", getOp.Parameters[0].In)
+	}
+
+	postOp := doc.Paths["/users"]["post"]
+	assert.NotNil(t, postOp)
+	assert.Empty(t, postOp.Summary)
+}
+
+func TestGenerate_SkipsMountEntries(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Mount("/static", http.NotFoundHandler())
+	r.Get("/users", func(w http.ResponseWriter, req *http.Request) {})
+
+	doc, err := openapi.Generate(r, openapi.Info{Title: "Test API", Version: "1.0.0"})
+	assert.NoError(t, err)
+
+	_, ok := doc.Paths["/static/"]
+	assert.False(t, ok, "Mount entries have no HTTP method and shouldn't produce a path item")
+	assert.Contains(t, doc.Paths, "/users")
+}
@@ -0,0 +1,19 @@
+package light
+
+// Operation describes the OpenAPI metadata for a single route, attached via
+// Router.Describe and surfaced to subpackages such as gohttp/openapi through
+// Router.Operation. gohttp/openapi.Operation is an alias of this type, so
+// either spelling can be used when calling Describe.
+type Operation struct {
+	// Summary is a short, one-line description of the operation.
+	Summary string
+	// Description is the long-form explanation of the operation.
+	Description string
+	// Tags groups the operation under the given OpenAPI tags.
+	Tags []string
+	// RequestBody is marshalled into the operation's requestBody schema.
+	RequestBody any
+	// Responses maps HTTP status codes to the response body marshalled
+	// into that status code's schema.
+	Responses map[int]any
+}
@@ -0,0 +1,54 @@
+package light
+
+import "net/http"
+
+type routeContextKey struct{}
+
+// RouteCtx is the per-request routing metadata a Router stashes before
+// running the handler for the route it matched. Reporting Pattern instead
+// of the concrete request path lets middlewares such as a request logger or
+// a metrics recorder report "GET /users/{id}" rather than the literal URL,
+// avoiding high-cardinality labels.
+type RouteCtx struct {
+	// Pattern is the absolute pattern the matched route was registered
+	// with, e.g. "/users/{id}".
+	Pattern string
+	// Method is the HTTP method the matched route was registered for.
+	Method string
+	// Middlewares is the middleware chain that applied to the matched
+	// route at registration time.
+	Middlewares []func(http.Handler) http.Handler
+
+	err error
+}
+
+// Err returns the error the matched route's HandlerFunc returned, if it was
+// registered via MethodE/GetE/PostE/..., letting middlewares such as a
+// Recoverer or metrics recorder observe it without parsing the response.
+func (rc *RouteCtx) Err() error {
+	return rc.err
+}
+
+// RouteContext returns the RouteCtx a Router stashed for r. ok is false
+// unless r is (or derives from) the request a Router's ServeHTTP passed down
+// to its middlewares and handlers; the caller's own request passed into
+// ServeHTTP does not carry it.
+func RouteContext(r *http.Request) (rc *RouteCtx, ok bool) {
+	rc, ok = r.Context().Value(routeContextKey{}).(*RouteCtx)
+	return rc, ok
+}
+
+// stashRouteContext wraps h with an internal middleware that fills in the
+// RouteCtx a Router's ServeHTTP already attached to the request, using the
+// absolute pattern and middleware chain resolved at Method() registration
+// time. It runs last, immediately before h.
+func stashRouteContext(method, pattern string, middlewares []func(http.Handler) http.Handler, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rc, ok := RouteContext(r); ok {
+			rc.Method = method
+			rc.Pattern = pattern
+			rc.Middlewares = middlewares
+		}
+		h.ServeHTTP(w, r)
+	})
+}
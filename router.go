@@ -3,6 +3,7 @@ package light
 import (
 	"fmt"
 	"net/http"
+	"sync"
 )
 
 // Router consisting of the core routing methods used by chi's Mux,
@@ -38,17 +39,118 @@ type Router interface {
 	Post(pattern string, h http.HandlerFunc)
 	Put(pattern string, h http.HandlerFunc)
 	Trace(pattern string, h http.HandlerFunc)
+
+	// Walk calls fn for every route registered on the Router (and any
+	// Group/Route it was built from), passing the resolved absolute
+	// pattern, the final handler and the middleware chain that applied
+	// to it at registration time. It stops and returns the first error
+	// fn returns. Walk is the introspection hook subpackages such as
+	// gohttp/openapi build on to generate documentation without
+	// re-parsing routes.
+	Walk(fn func(method, pattern string, handler http.Handler, middlewares []func(http.Handler) http.Handler) error) error
+
+	// Describe attaches OpenAPI metadata to the route registered for
+	// method and pattern. It is a no-op if no such route exists yet,
+	// so Describe calls should follow the matching Method/Get/Post/...
+	// call. Walk consumers use Operation to retrieve what was attached.
+	Describe(method, pattern string, op Operation)
+
+	// Operation returns the metadata attached via Describe for the
+	// route registered for method and pattern, if any.
+	Operation(method, pattern string) (Operation, bool)
+
+	// Mount attaches h under pattern, like chi's Mux.Mount: h receives
+	// requests with pattern stripped from the front of the URL path, and
+	// is reachable both at the bare pattern and any subpath of it.
+	Mount(pattern string, h http.Handler)
+
+	// NotFound registers a handler run (through the accumulated
+	// middleware stack) in place of http.ServeMux's default 404 response.
+	NotFound(h http.HandlerFunc)
+
+	// MethodNotAllowed registers a handler run (through the accumulated
+	// middleware stack) in place of http.ServeMux's default 405 response.
+	MethodNotAllowed(h http.HandlerFunc)
+
+	// SetErrorHandler sets the ErrorHandler MethodE/GetE/PostE/... call
+	// when their HandlerFunc returns a non-nil error. It is inherited by
+	// any Group/Route/With built from this Router afterwards, and can be
+	// overridden per subtree by calling SetErrorHandler again on the
+	// child.
+	SetErrorHandler(eh ErrorHandler)
+
+	// MethodE and the HTTP-method-specific *E methods below register a
+	// HandlerFunc instead of an http.HandlerFunc: when it returns an
+	// error, the Router's ErrorHandler renders the response instead of
+	// the handler doing so itself.
+	MethodE(method, pattern string, h HandlerFunc)
+	ConnectE(pattern string, h HandlerFunc)
+	DeleteE(pattern string, h HandlerFunc)
+	GetE(pattern string, h HandlerFunc)
+	HeadE(pattern string, h HandlerFunc)
+	OptionsE(pattern string, h HandlerFunc)
+	PatchE(pattern string, h HandlerFunc)
+	PostE(pattern string, h HandlerFunc)
+	PutE(pattern string, h HandlerFunc)
+	TraceE(pattern string, h HandlerFunc)
+}
+
+type routeEntry struct {
+	method      string
+	pattern     string
+	handler     http.Handler
+	middlewares []func(http.Handler) http.Handler
+	operation   *Operation
+}
+
+// routeRegistry accumulates routeEntry values as Method is called across a
+// Router and all the sub-Routers derived from it via Group/Route/With, since
+// they all share the same underlying *http.ServeMux.
+type routeRegistry struct {
+	mu               sync.Mutex
+	routes           []*routeEntry
+	notFound         http.Handler
+	methodNotAllowed http.Handler
+}
+
+func (reg *routeRegistry) add(entry *routeEntry) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.routes = append(reg.routes, entry)
+}
+
+func (reg *routeRegistry) find(method, pattern string) *routeEntry {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	for _, entry := range reg.routes {
+		if entry.method == method && entry.pattern == pattern {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (reg *routeRegistry) snapshot() []*routeEntry {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	entries := make([]*routeEntry, len(reg.routes))
+	copy(entries, reg.routes)
+	return entries
 }
 
 type routerContext struct {
 	pattern string
 	*http.ServeMux
-	middlewares []func(next http.Handler) http.Handler
+	middlewares  []func(next http.Handler) http.Handler
+	registry     *routeRegistry
+	errorHandler ErrorHandler
 }
 
 func NewRouter() Router {
 	return &routerContext{
-		ServeMux: http.NewServeMux(),
+		ServeMux:     http.NewServeMux(),
+		registry:     &routeRegistry{},
+		errorHandler: defaultErrorHandler,
 	}
 }
 
@@ -58,8 +160,10 @@ func (r *routerContext) Use(middlewares ...func(next http.Handler) http.Handler)
 
 func (r *routerContext) With(middlewares ...func(next http.Handler) http.Handler) Router {
 	nr := &routerContext{
-		ServeMux: r.ServeMux,
-		pattern:  r.pattern,
+		ServeMux:     r.ServeMux,
+		pattern:      r.pattern,
+		registry:     r.registry,
+		errorHandler: r.errorHandler,
 	}
 	nr.middlewares = append(nr.middlewares, r.middlewares...)
 	nr.middlewares = append(nr.middlewares, middlewares...)
@@ -68,8 +172,10 @@ func (r *routerContext) With(middlewares ...func(next http.Handler) http.Handler
 
 func (r *routerContext) Group(fn func(r Router)) Router {
 	nr := &routerContext{
-		ServeMux: r.ServeMux,
-		pattern:  r.pattern,
+		ServeMux:     r.ServeMux,
+		pattern:      r.pattern,
+		registry:     r.registry,
+		errorHandler: r.errorHandler,
 	}
 	nr.middlewares = append(nr.middlewares, r.middlewares...)
 	fn(nr)
@@ -78,8 +184,10 @@ func (r *routerContext) Group(fn func(r Router)) Router {
 
 func (r *routerContext) Route(pattern string, fn func(r Router)) Router {
 	nr := &routerContext{
-		ServeMux: r.ServeMux,
-		pattern:  fmt.Sprintf("%s%s", r.pattern, pattern),
+		ServeMux:     r.ServeMux,
+		pattern:      fmt.Sprintf("%s%s", r.pattern, pattern),
+		registry:     r.registry,
+		errorHandler: r.errorHandler,
 	}
 	nr.middlewares = append(nr.middlewares, r.middlewares...)
 	fn(nr)
@@ -87,15 +195,26 @@ func (r *routerContext) Route(pattern string, fn func(r Router)) Router {
 }
 
 func (r *routerContext) Method(method, pattern string, h http.Handler) {
-	for i := len(r.middlewares) - 1; i >= 0; i-- {
-		h = r.middlewares[i](h)
-	}
+	fullPattern := fmt.Sprintf("%s%s", r.pattern, pattern)
 
-	if method != "" {
-		method = fmt.Sprintf("%s ", method)
+	middlewares := make([]func(http.Handler) http.Handler, len(r.middlewares))
+	copy(middlewares, r.middlewares)
+
+	r.registry.add(&routeEntry{
+		method:      method,
+		pattern:     fullPattern,
+		handler:     h,
+		middlewares: middlewares,
+	})
+
+	wrapped := r.wrapMiddlewares(stashRouteContext(method, fullPattern, middlewares, h))
+
+	servePattern := method
+	if servePattern != "" {
+		servePattern = fmt.Sprintf("%s ", servePattern)
 	}
 
-	r.Handle(fmt.Sprintf("%s%s%s", method, r.pattern, pattern), h)
+	r.Handle(fmt.Sprintf("%s%s", servePattern, fullPattern), wrapped)
 }
 
 func (r *routerContext) MethodFunc(method, pattern string, h http.HandlerFunc) {
@@ -137,3 +256,28 @@ func (r *routerContext) Put(pattern string, h http.HandlerFunc) {
 func (r *routerContext) Trace(pattern string, h http.HandlerFunc) {
 	r.MethodFunc(http.MethodTrace, pattern, h)
 }
+
+func (r *routerContext) Walk(fn func(method, pattern string, handler http.Handler, middlewares []func(http.Handler) http.Handler) error) error {
+	for _, entry := range r.registry.snapshot() {
+		if err := fn(entry.method, entry.pattern, entry.handler, entry.middlewares); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *routerContext) Describe(method, pattern string, op Operation) {
+	entry := r.registry.find(method, fmt.Sprintf("%s%s", r.pattern, pattern))
+	if entry == nil {
+		return
+	}
+	entry.operation = &op
+}
+
+func (r *routerContext) Operation(method, pattern string) (Operation, bool) {
+	entry := r.registry.find(method, pattern)
+	if entry == nil || entry.operation == nil {
+		return Operation{}, false
+	}
+	return *entry.operation, true
+}
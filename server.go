@@ -0,0 +1,152 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Server wraps http.Server and a Router with graceful shutdown: on a
+// configured signal (SIGINT/SIGTERM by default) it stops accepting new
+// connections and drains in-flight ones for up to GracefulTimeout before
+// ListenAndServe returns. This is the blessed way to run a light app
+// end-to-end.
+type Server struct {
+	// Addr is the TCP address to listen on, passed straight to
+	// http.Server.Addr.
+	Addr string
+	// Router handles incoming requests.
+	Router Router
+
+	// Signals are the signals that trigger a graceful shutdown. Defaults
+	// to os.Interrupt and syscall.SIGTERM.
+	Signals []os.Signal
+	// GracefulTimeout bounds how long Shutdown waits for in-flight
+	// requests to finish before giving up. Defaults to 15s.
+	GracefulTimeout time.Duration
+	// PreStop is slept after a shutdown signal arrives but before the
+	// listener stops accepting connections, giving a load balancer time
+	// to deregister this instance first.
+	PreStop time.Duration
+
+	// ReadHeaderTimeout is passed straight to http.Server.ReadHeaderTimeout.
+	// Defaults to 10s, to mitigate Slowloris.
+	ReadHeaderTimeout time.Duration
+	// IdleTimeout is passed straight to http.Server.IdleTimeout. Defaults
+	// to 120s, so idle keep-alive connections can't pile up indefinitely.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes is passed straight to http.Server.MaxHeaderBytes.
+	MaxHeaderBytes int
+
+	onShutdown []func(ctx context.Context) error
+	srv        *http.Server
+}
+
+// OnShutdown registers fn to run during a graceful shutdown, after the
+// server stops accepting new connections but before ListenAndServe returns.
+// Use it to flush logs, close DB pools, and the like. fn gets its own
+// context bounded by GracefulTimeout, independent of (and not eaten into
+// by) however long draining in-flight requests took.
+func (s *Server) OnShutdown(fn func(ctx context.Context) error) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
+// ListenAndServe runs the server until ctx is cancelled or a configured
+// signal arrives, drains in-flight connections, runs the OnShutdown hooks,
+// and returns. A clean shutdown returns a nil error.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	return s.serve(ctx, func(srv *http.Server) error {
+		return srv.ListenAndServe()
+	})
+}
+
+// ListenAndServeTLS is ListenAndServe's TLS counterpart.
+func (s *Server) ListenAndServeTLS(ctx context.Context, certFile, keyFile string) error {
+	return s.serve(ctx, func(srv *http.Server) error {
+		return srv.ListenAndServeTLS(certFile, keyFile)
+	})
+}
+
+func (s *Server) serve(ctx context.Context, listen func(*http.Server) error) error {
+	signals := s.Signals
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, signals...)
+	defer stop()
+
+	readHeaderTimeout := s.ReadHeaderTimeout
+	if readHeaderTimeout == 0 {
+		readHeaderTimeout = 10 * time.Second
+	}
+
+	idleTimeout := s.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = 120 * time.Second
+	}
+
+	s.srv = &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Router,
+		ReadHeaderTimeout: readHeaderTimeout,
+		IdleTimeout:       idleTimeout,
+		MaxHeaderBytes:    s.MaxHeaderBytes,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- listen(s.srv)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	if s.PreStop > 0 {
+		time.Sleep(s.PreStop)
+	}
+
+	gracefulTimeout := s.GracefulTimeout
+	if gracefulTimeout == 0 {
+		gracefulTimeout = 15 * time.Second
+	}
+
+	// baseCtx, not shutdownCtx, is what the OnShutdown hooks get timed
+	// against: shutdownCtx may already be spent by the time Shutdown
+	// returns (in-flight requests are allowed to take the whole of
+	// GracefulTimeout to drain), and hooks that flush logs or close DB
+	// pools deserve their own full budget, not whatever's left.
+	baseCtx := context.WithoutCancel(ctx)
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(baseCtx, gracefulTimeout)
+	err := s.srv.Shutdown(shutdownCtx)
+	cancelShutdown()
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+
+	hooksCtx, cancelHooks := context.WithTimeout(baseCtx, gracefulTimeout)
+	defer cancelHooks()
+
+	for _, fn := range s.onShutdown {
+		if hookErr := fn(hooksCtx); hookErr != nil && err == nil {
+			err = hookErr
+		}
+	}
+
+	if listenErr := <-serveErr; err == nil && !errors.Is(listenErr, http.ErrServerClosed) {
+		err = listenErr
+	}
+
+	return err
+}
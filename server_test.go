@@ -0,0 +1,48 @@
+package light_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gohttp "github.com/hysem/go-http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GracefulShutdown(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/ping", func(w http.ResponseWriter, req *http.Request) {})
+
+	srv := &gohttp.Server{
+		Addr:            "127.0.0.1:0",
+		Router:          r,
+		GracefulTimeout: time.Second,
+	}
+
+	var shutdownCalled atomic.Bool
+	srv.OnShutdown(func(ctx context.Context) error {
+		shutdownCalled.Store(true)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not shut down in time")
+	}
+
+	assert.True(t, shutdownCalled.Load())
+}
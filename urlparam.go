@@ -0,0 +1,92 @@
+package light
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ParamError is returned by the typed URLParam accessors when the path
+// value for Key fails to parse as the requested type.
+type ParamError struct {
+	Key   string
+	Value string
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("light: invalid value %q for url param %q: %v", e.Value, e.Key, e.Err)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// URLParam returns the value net/http's ServeMux parsed for the {key}
+// wildcard segment of the matched pattern, or "" if key wasn't part of it.
+func URLParam(r *http.Request, key string) string {
+	return r.PathValue(key)
+}
+
+// URLParamInt returns the {key} wildcard value parsed as an int.
+func URLParamInt(r *http.Request, key string) (int, error) {
+	v := r.PathValue(key)
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, &ParamError{Key: key, Value: v, Err: err}
+	}
+	return n, nil
+}
+
+// URLParamInt64 returns the {key} wildcard value parsed as an int64.
+func URLParamInt64(r *http.Request, key string) (int64, error) {
+	v := r.PathValue(key)
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, &ParamError{Key: key, Value: v, Err: err}
+	}
+	return n, nil
+}
+
+// UUID is a 16-byte RFC 4122 UUID.
+type UUID [16]byte
+
+// String formats id in its canonical 8-4-4-4-12 hyphenated form.
+func (id UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], id[10:16])
+	return string(buf[:])
+}
+
+// URLParamUUID returns the {key} wildcard value parsed as a canonical,
+// hyphenated UUID.
+func URLParamUUID(r *http.Request, key string) (UUID, error) {
+	v := r.PathValue(key)
+	id, err := parseUUID(v)
+	if err != nil {
+		return UUID{}, &ParamError{Key: key, Value: v, Err: err}
+	}
+	return id, nil
+}
+
+func parseUUID(s string) (UUID, error) {
+	var id UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return id, fmt.Errorf("invalid UUID format")
+	}
+
+	hexDigits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(id[:], []byte(hexDigits)); err != nil {
+		return id, err
+	}
+	return id, nil
+}
@@ -0,0 +1,52 @@
+package light_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gohttp "github.com/hysem/go-http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLParam(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users/{id}", func(w http.ResponseWriter, req *http.Request) {
+		id, err := gohttp.URLParamInt64(req, "id")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), id)
+		assert.Equal(t, "42", gohttp.URLParam(req, "id"))
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	r.ServeHTTP(rec, req)
+}
+
+func TestURLParamInt64_Invalid(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users/{id}", func(w http.ResponseWriter, req *http.Request) {
+		_, err := gohttp.URLParamInt64(req, "id")
+		var paramErr *gohttp.ParamError
+		assert.ErrorAs(t, err, &paramErr)
+		assert.Equal(t, "id", paramErr.Key)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/not-a-number", nil)
+	r.ServeHTTP(rec, req)
+}
+
+func TestRouteContext(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users/{id}", func(w http.ResponseWriter, req *http.Request) {
+		rc, ok := gohttp.RouteContext(req)
+		assert.True(t, ok)
+		assert.Equal(t, http.MethodGet, rc.Method)
+		assert.Equal(t, "/users/{id}", rc.Pattern)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	r.ServeHTTP(rec, req)
+}
@@ -0,0 +1,62 @@
+package light_test
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+
+	gohttp "github.com/hysem/go-http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Walk(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users", handler)
+	r.Post("/users", handler)
+	r.Route("/v1", func(r gohttp.Router) {
+		r.Get("/health", handler)
+	})
+
+	var got []string
+	err := r.Walk(func(method, pattern string, h http.Handler, mw []func(http.Handler) http.Handler) error {
+		got = append(got, method+" "+pattern)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	sort.Strings(got)
+	assert.Equal(t, []string{"GET /users", "GET /v1/health", "POST /users"}, got)
+}
+
+func TestRouter_Walk_PropagatesError(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users", handler)
+
+	boom := assert.AnError
+	err := r.Walk(func(method, pattern string, h http.Handler, mw []func(http.Handler) http.Handler) error {
+		return boom
+	})
+
+	assert.Equal(t, boom, err)
+}
+
+func TestRouter_Describe(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Get("/users/{id}", handler)
+	r.Describe(http.MethodGet, "/users/{id}", gohttp.Operation{Summary: "Get a user"})
+
+	op, ok := r.Operation(http.MethodGet, "/users/{id}")
+	assert.True(t, ok)
+	assert.Equal(t, "Get a user", op.Summary)
+
+	_, ok = r.Operation(http.MethodPost, "/users/{id}")
+	assert.False(t, ok)
+}
+
+func TestRouter_Describe_UnknownRouteIsNoop(t *testing.T) {
+	r := gohttp.NewRouter()
+	r.Describe(http.MethodGet, "/never-registered", gohttp.Operation{Summary: "nope"})
+
+	_, ok := r.Operation(http.MethodGet, "/never-registered")
+	assert.False(t, ok)
+}